@@ -0,0 +1,17 @@
+package gosseract
+
+import "image"
+
+// BoundingBox is one element yielded while walking a ResultIterator at a
+// given PageIteratorLevel - a word, line, block, etc. - together with its
+// recognized text, confidence, and position on the page.
+type BoundingBox struct {
+	Word       string
+	Confidence float64
+	Box        image.Rectangle
+
+	// BaselineY1 and BaselineY2 are the y-coordinates of the left and right
+	// ends of the recognized baseline, as returned by PageIterator::Baseline.
+	BaselineY1 int
+	BaselineY2 int
+}
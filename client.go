@@ -11,9 +11,12 @@ package gosseract
 // #include "tessbridge.h"
 import "C"
 import (
+	"context"
 	"fmt"
+	"image"
 	"os"
 	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -50,7 +53,7 @@ type Client struct {
 
 	// TessdataPrefix can indicate directory path to `tessdata`.
 	// It is set `/usr/local/share/tessdata/` or something like that, as default.
-	// TODO: Implement and test
+	// UseEmbeddedTessdata sets this automatically from registered embedded data.
 	TessdataPrefix *string
 
 	// Languages are languages to be detected. If not specified, it's gonna be "eng".
@@ -62,6 +65,12 @@ type Client struct {
 	// ImageData is the in-memory image to be processed OCR.
 	ImageData []byte
 
+	// rawPixData, if set by SetImageFromPix, is an already-decoded pixel
+	// buffer (e.g. image.RGBA.Pix) to be handed straight to Tesseract,
+	// skipping the PNG/JPEG encode/decode ImageData otherwise requires.
+	rawPixData                                         []byte
+	rawPixWidth, rawPixHeight, rawPixBPP, rawPixStride int
+
 	// Variables is just a pool to evaluate "tesseract::TessBaseAPI->SetVariable" in delay.
 	// TODO: Think if it should be public, or private property.
 	Variables map[SettableVariable]string
@@ -74,6 +83,25 @@ type Client struct {
 	// See http://www.sk-spell.sk.cx/tesseract-ocr-parameters-in-302-version
 	// TODO: Fix link to official page
 	ConfigFilePath string
+
+	// rectangle restricts recognition to a sub-region of the image, as set by
+	// SetRectangle. It's applied to the image inside `prepare`.
+	rectangle *image.Rectangle
+
+	// usingEmbeddedTessdata records that UseEmbeddedTessdata was called, so
+	// `init` knows to extract and point TessdataPrefix at the registered
+	// traineddata files.
+	usingEmbeddedTessdata bool
+
+	// embeddedTessdataAcquired records that this client holds a reference on
+	// the shared embedded tessdata directory, so Close can release it.
+	embeddedTessdataAcquired bool
+
+	// preprocessOptions is set by SetPreprocessing. preprocessed caches
+	// whether the pipeline already ran on the current pixImage, so it isn't
+	// re-applied on every Text() call.
+	preprocessOptions *PreprocessOptions
+	preprocessed      bool
 }
 
 // NewClient construct new Client. It's due to caller to Close this client.
@@ -99,6 +127,10 @@ func (client *Client) Close() (err error) {
 		C.DestroyPixImage(client.pixImage)
 		client.pixImage = nil
 	}
+	if client.embeddedTessdataAcquired {
+		releaseEmbeddedTessdataDir()
+		client.embeddedTessdataAcquired = false
+	}
 	return err
 }
 
@@ -109,6 +141,9 @@ func (client *Client) SetImage(imagepath string) *Client {
 		client.pixImage = nil
 	}
 	client.ImagePath = imagepath
+	client.rawPixData = nil
+	client.rectangle = nil
+	client.preprocessed = false
 	return client
 }
 
@@ -119,6 +154,49 @@ func (client *Client) SetImageFromBytes(data []byte) *Client {
 		client.pixImage = nil
 	}
 	client.ImageData = data
+	client.rawPixData = nil
+	client.rectangle = nil
+	client.preprocessed = false
+	return client
+}
+
+// SetImageFromPix sets an already-decoded pixel buffer (e.g. image.RGBA.Pix
+// or a raw video frame) to be processed OCR, bypassing the PNG/JPEG
+// encode/decode round-trip SetImageFromBytes otherwise requires.
+// bytesPerPixel and bytesPerLine describe data's layout, mirroring
+// TessBaseAPI::SetImage(const unsigned char*, int, int, int, int).
+func (client *Client) SetImageFromPix(width, height, bytesPerPixel, bytesPerLine int, data []byte) *Client {
+	if client.pixImage != nil {
+		C.DestroyPixImage(client.pixImage)
+		client.pixImage = nil
+	}
+	client.ImagePath = ""
+	client.ImageData = nil
+	client.rawPixData = data
+	client.rawPixWidth = width
+	client.rawPixHeight = height
+	client.rawPixBPP = bytesPerPixel
+	client.rawPixStride = bytesPerLine
+	client.rectangle = nil
+	client.preprocessed = false
+	return client
+}
+
+// SetRectangle restricts recognition to the sub-region (x, y, w, h) of the
+// currently set image, mirroring TessBaseAPI::SetRectangle. It's applied
+// inside `prepare`, after the image is set but before Text()/HOCRText()
+// execute, so the same loaded image can be re-OCR'd across multiple regions
+// without reloading pixels. Use ClearRectangle to go back to the full image.
+func (client *Client) SetRectangle(x, y, w, h int) *Client {
+	rect := image.Rect(x, y, x+w, y+h)
+	client.rectangle = &rect
+	return client
+}
+
+// ClearRectangle removes any rectangle set by SetRectangle, so the next
+// Text()/HOCRText() call recognizes the full image again.
+func (client *Client) ClearRectangle() *Client {
+	client.rectangle = nil
 	return client
 }
 
@@ -188,16 +266,32 @@ func (client *Client) charConfig() *C.char {
 }
 
 // Initialize tesseract::TessBaseAPI
-// TODO: add tessdata prefix
 func (client *Client) init() error {
 	if client.Initialized {
 		return nil
 	}
+
+	if client.usingEmbeddedTessdata && client.TessdataPrefix == nil {
+		dir, err := acquireEmbeddedTessdataDir()
+		if err != nil {
+			return err
+		}
+		client.embeddedTessdataAcquired = true
+		client.TessdataPrefix = &dir
+	}
+
 	langs := client.charLangs()
 	defer C.free(unsafe.Pointer(langs))
 	config := client.charConfig()
 	defer C.free(unsafe.Pointer(config))
-	res := C.Init(client.api, nil, langs, config)
+
+	var datapath *C.char
+	if client.TessdataPrefix != nil {
+		datapath = C.CString(*client.TessdataPrefix)
+		defer C.free(unsafe.Pointer(datapath))
+	}
+
+	res := C.Init(client.api, datapath, langs, config)
 	if res != 0 {
 		// TODO: capture and vacuum stderr from Cgo
 		return fmt.Errorf("failed to initialize TessBaseAPI with code %d", res)
@@ -218,6 +312,31 @@ func (client *Client) prepare() error {
 				C.int(len(client.ImageData)),
 			)
 			client.pixImage = img
+		} else if len(client.rawPixData) > 0 {
+			switch client.rawPixBPP {
+			case 1, 2, 3, 4:
+			default:
+				return fmt.Errorf("unsupported bytesPerPixel %d, must be 1, 2, 3 or 4", client.rawPixBPP)
+			}
+			if client.rawPixStride < client.rawPixWidth*client.rawPixBPP {
+				return fmt.Errorf("bytesPerLine %d is too small for width %d at %d bytes per pixel", client.rawPixStride, client.rawPixWidth, client.rawPixBPP)
+			}
+			if needed := client.rawPixHeight * client.rawPixStride; len(client.rawPixData) < needed {
+				return fmt.Errorf("pixel buffer too small: need at least %d bytes for %dx%d at stride %d, got %d", needed, client.rawPixWidth, client.rawPixHeight, client.rawPixStride, len(client.rawPixData))
+			}
+
+			img := C.SetImageFromRawData(
+				client.api,
+				(*C.uchar)(unsafe.Pointer(&client.rawPixData[0])),
+				C.int(client.rawPixWidth),
+				C.int(client.rawPixHeight),
+				C.int(client.rawPixBPP),
+				C.int(client.rawPixStride),
+			)
+			if img == nil {
+				return fmt.Errorf("failed to build pix image from raw data")
+			}
+			client.pixImage = img
 		} else {
 			// Set Image by giving path
 			if client.ImagePath == "" {
@@ -231,8 +350,14 @@ func (client *Client) prepare() error {
 			img := C.SetImage(client.api, imagepath)
 			client.pixImage = img
 		}
-	} else {
-		C.SetPixImage(client.api, client.pixImage)
+	}
+
+	client.preprocess()
+	C.SetPixImage(client.api, client.pixImage)
+
+	if client.rectangle != nil {
+		rect := client.rectangle
+		C.SetRectangle(client.api, C.int(rect.Min.X), C.int(rect.Min.Y), C.int(rect.Dx()), C.int(rect.Dy()))
 	}
 
 	for key, value := range client.Variables {
@@ -285,3 +410,172 @@ func (client *Client) HOCRText() (out string, err error) {
 	out = C.GoString(C.HOCRText(client.api))
 	return
 }
+
+// ProcessPages runs tesseract::TessBaseAPI::ProcessPages over a whole document -
+// a multi-page TIFF, a PDF, or a plain-text file listing image paths, one per
+// line - and renders it directly to outputBase using one TessResultRenderer per
+// requested format (e.g. outputBase+".pdf", outputBase+".tsv", ...).
+// At least one format must be given.
+func (client *Client) ProcessPages(inputPath string, outputBase string, formats ...OutputFormat) error {
+	return client.ProcessPagesTimeout(context.Background(), inputPath, outputBase, formats...)
+}
+
+// ProcessPagesTimeout is ProcessPages with Tesseract's timeout_millisec parameter
+// surfaced via ctx: the time left until ctx's deadline is translated to
+// milliseconds for the C++ side, which is what actually aborts recognition.
+// Cancelling ctx does not interrupt the running call - TessBaseAPI isn't safe
+// to touch (e.g. via Close) while a call is in flight, so this always waits
+// for the underlying C++ call to finish and then returns ctx.Err().
+func (client *Client) ProcessPagesTimeout(ctx context.Context, inputPath string, outputBase string, formats ...OutputFormat) error {
+	if len(formats) == 0 {
+		return fmt.Errorf("at least one OutputFormat is required")
+	}
+	if err := client.init(); err != nil {
+		return err
+	}
+
+	var formatMask C.int
+	for _, format := range formats {
+		formatMask |= C.int(format)
+	}
+
+	// Tesseract only enforces timeout_millisec when it's > 0; 0 means "run to
+	// completion, no bound." A ctx without a deadline is passed through as 0:
+	// timeout_millisec is a time budget, not a polling interval, so any small
+	// bound would cut recognition short. A deadline that has already passed
+	// is clamped to a minimal positive bound so Tesseract gives up at once
+	// instead of running unbounded.
+	const minTimeoutMillisec = 1
+	timeoutMillisec := 0
+	if deadline, ok := ctx.Deadline(); ok {
+		timeoutMillisec = int(time.Until(deadline) / time.Millisecond)
+		if timeoutMillisec <= 0 {
+			timeoutMillisec = minTimeoutMillisec
+		}
+	}
+
+	input := C.CString(inputPath)
+	defer C.free(unsafe.Pointer(input))
+	output := C.CString(outputBase)
+	defer C.free(unsafe.Pointer(output))
+
+	done := make(chan C.int, 1)
+	go func() {
+		done <- C.ProcessPages(client.api, input, output, C.int(timeoutMillisec), formatMask)
+	}()
+
+	var res C.int
+	select {
+	case <-ctx.Done():
+		// Wait for the in-flight call to actually finish - client.api must
+		// not be touched (e.g. via Close or another OCR call) while it's
+		// still running - and report the earlier context error once it has.
+		res = <-done
+		return ctx.Err()
+	case res = <-done:
+	}
+
+	switch res {
+	case 0:
+		return nil
+	case -1:
+		return fmt.Errorf("no output format selected for ProcessPages")
+	default:
+		return fmt.Errorf("failed to process pages with code %d", int(res))
+	}
+}
+
+// GetBoundingBoxes runs Text() and then walks the resulting ResultIterator at
+// the given level, collecting every element's bounding box, baseline,
+// confidence and text.
+func (client *Client) GetBoundingBoxes(level PageIteratorLevel) (out []BoundingBox, err error) {
+	err = client.Iterate(level, func(box BoundingBox) bool {
+		out = append(out, box)
+		return true
+	})
+	return
+}
+
+// Iterate runs Text() and streams each element at the given PageIteratorLevel
+// to f, stopping early as soon as f returns false.
+func (client *Client) Iterate(level PageIteratorLevel, f func(BoundingBox) bool) error {
+	if err := client.init(); err != nil {
+		return err
+	}
+	if err := client.prepare(); err != nil {
+		return err
+	}
+	// GetIterator() only returns a populated iterator once Recognize() has
+	// run, so force recognition the same way Text() does before requesting
+	// one.
+	C.UTF8Text(client.api)
+
+	iterator := C.CreateResultIterator(client.api)
+	if iterator == nil {
+		return fmt.Errorf("failed to create result iterator")
+	}
+	defer C.DestroyResultIterator(iterator)
+
+	clevel := C.int(level)
+	for {
+		var left, top, right, bottom C.int
+		hasBox := bool(C.IteratorBoundingBox(iterator, clevel, &left, &top, &right, &bottom))
+
+		if hasBox {
+			text := C.IteratorText(iterator, clevel)
+			confidence := C.IteratorConfidence(iterator, clevel)
+
+			var x1, y1, x2, y2 C.int
+			if !bool(C.IteratorBaseline(iterator, clevel, &x1, &y1, &x2, &y2)) {
+				// No baseline at this level/position (e.g. non-text blocks) -
+				// leave it explicitly zeroed rather than reporting stale data.
+				x1, y1, x2, y2 = 0, 0, 0, 0
+			}
+
+			box := BoundingBox{
+				Word:       C.GoString(text),
+				Confidence: float64(confidence),
+				Box:        image.Rect(int(left), int(top), int(right), int(bottom)),
+				BaselineY1: int(y1),
+				BaselineY2: int(y2),
+			}
+			if !f(box) {
+				return nil
+			}
+		}
+
+		if !bool(C.IteratorNext(iterator, clevel)) {
+			return nil
+		}
+	}
+}
+
+// DetectOrientation runs tesseract::TessBaseAPI::DetectOrientationScript over
+// the currently set image and reports its rotation and script. It can be used
+// standalone (e.g. combined with SetPageSegMode(PSM_OSD_ONLY)) or after a
+// normal Text() call against the same image. It requires the "osd"
+// traineddata to be installed alongside the client's language(s).
+func (client *Client) DetectOrientation() (*OrientationResult, error) {
+	if err := client.init(); err != nil {
+		return nil, err
+	}
+	if err := client.prepare(); err != nil {
+		return nil, err
+	}
+
+	var orientDeg C.int
+	var orientConfidence, scriptConfidence C.double
+	var scriptName *C.char
+
+	ok := C.DetectOrientation(client.api, &orientDeg, &orientConfidence, &scriptName, &scriptConfidence)
+	if !bool(ok) {
+		return nil, fmt.Errorf("failed to detect orientation and script, is the \"osd\" traineddata installed?")
+	}
+
+	return &OrientationResult{
+		OrientDeg:        int(orientDeg),
+		OrientConfidence: float64(orientConfidence),
+		ScriptName:       C.GoString(scriptName),
+		ScriptConfidence: float64(scriptConfidence),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package gosseract
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func benchmarkRGBAImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	return img
+}
+
+// BenchmarkSetImageFromBytesPNGEncode measures the path SetImageFromPix was
+// added to avoid: encoding an already-decoded image.RGBA to PNG, then having
+// prepare() decode it straight back into a Pix via pixReadMem.
+func BenchmarkSetImageFromBytesPNGEncode(b *testing.B) {
+	img := benchmarkRGBAImage()
+	client := NewClient()
+	defer client.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			b.Fatalf("png.Encode returned an error: %v", err)
+		}
+		client.SetImageFromBytes(buf.Bytes())
+		if err := client.prepare(); err != nil {
+			b.Fatalf("prepare returned an error: %v", err)
+		}
+	}
+}
+
+// BenchmarkSetImageFromPixRaw measures handing the same image.RGBA.Pix buffer
+// straight to SetImageFromPix, so prepare() builds the Pix straight from the
+// raw buffer instead, skipping the PNG encode/decode round-trip above
+// entirely.
+func BenchmarkSetImageFromPixRaw(b *testing.B) {
+	img := benchmarkRGBAImage()
+	client := NewClient()
+	defer client.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		client.SetImageFromPix(img.Rect.Dx(), img.Rect.Dy(), 4, img.Stride, img.Pix)
+		if err := client.prepare(); err != nil {
+			b.Fatalf("prepare returned an error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package gosseract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hasmorebug/gosseract/tessdata"
+)
+
+// embeddedTessdataDir and embeddedTessdataRefs share a single extracted copy
+// of the embedded traineddata files across every client in the process, and
+// are removed once the last client using them closes.
+var (
+	embeddedTessdataMu   sync.Mutex
+	embeddedTessdataDir  string
+	embeddedTessdataRefs int
+)
+
+// UseEmbeddedTessdata marks this client to use traineddata registered via the
+// gosseract/tessdata subpackage instead of a tessdata directory on disk. The
+// registered files are materialized into a per-process temp directory on
+// first use, and TessdataPrefix is pointed at it for the next Init() call.
+func (client *Client) UseEmbeddedTessdata() *Client {
+	client.usingEmbeddedTessdata = true
+	return client
+}
+
+func acquireEmbeddedTessdataDir() (string, error) {
+	embeddedTessdataMu.Lock()
+	defer embeddedTessdataMu.Unlock()
+
+	if embeddedTessdataDir != "" {
+		embeddedTessdataRefs++
+		return embeddedTessdataDir, nil
+	}
+
+	files := tessdata.All()
+	if len(files) == 0 {
+		return "", fmt.Errorf("no traineddata registered with gosseract/tessdata; call tessdata.Register or tessdata.Embed first")
+	}
+
+	dir, err := os.MkdirTemp("", "gosseract-tessdata-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create embedded tessdata directory: %w", err)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("failed to extract embedded tessdata file %q: %w", name, err)
+		}
+	}
+
+	embeddedTessdataDir = dir
+	embeddedTessdataRefs++
+	return dir, nil
+}
+
+func releaseEmbeddedTessdataDir() {
+	embeddedTessdataMu.Lock()
+	defer embeddedTessdataMu.Unlock()
+
+	if embeddedTessdataDir == "" {
+		return
+	}
+	embeddedTessdataRefs--
+	if embeddedTessdataRefs <= 0 {
+		os.RemoveAll(embeddedTessdataDir)
+		embeddedTessdataDir = ""
+		embeddedTessdataRefs = 0
+	}
+}
@@ -0,0 +1,55 @@
+package gosseract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hasmorebug/gosseract/tessdata"
+)
+
+func resetEmbeddedTessdataState(t *testing.T) {
+	t.Helper()
+	embeddedTessdataMu.Lock()
+	embeddedTessdataDir = ""
+	embeddedTessdataRefs = 0
+	embeddedTessdataMu.Unlock()
+}
+
+func TestAcquireEmbeddedTessdataDirRequiresRegisteredData(t *testing.T) {
+	resetEmbeddedTessdataState(t)
+	if _, err := acquireEmbeddedTessdataDir(); err == nil {
+		t.Fatal("expected an error when no traineddata has been registered")
+	}
+}
+
+func TestAcquireAndReleaseEmbeddedTessdataDirSharesAndCleansUp(t *testing.T) {
+	resetEmbeddedTessdataState(t)
+	tessdata.Register("eng.traineddata", []byte("eng-data"))
+
+	dir1, err := acquireEmbeddedTessdataDir()
+	if err != nil {
+		t.Fatalf("acquireEmbeddedTessdataDir returned an error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir1, "eng.traineddata")); err != nil {
+		t.Fatalf("expected eng.traineddata to be extracted into %s: %v", dir1, err)
+	}
+
+	dir2, err := acquireEmbeddedTessdataDir()
+	if err != nil {
+		t.Fatalf("acquireEmbeddedTessdataDir returned an error: %v", err)
+	}
+	if dir1 != dir2 {
+		t.Fatalf("expected the same shared directory, got %q and %q", dir1, dir2)
+	}
+
+	releaseEmbeddedTessdataDir()
+	if _, err := os.Stat(dir1); err != nil {
+		t.Fatalf("directory should still exist while a reference remains: %v", err)
+	}
+
+	releaseEmbeddedTessdataDir()
+	if _, err := os.Stat(dir1); !os.IsNotExist(err) {
+		t.Fatalf("expected directory to be removed once the last reference releases, stat err: %v", err)
+	}
+}
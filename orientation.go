@@ -0,0 +1,9 @@
+package gosseract
+
+// OrientationResult is the result of tesseract::TessBaseAPI::DetectOrientationScript.
+type OrientationResult struct {
+	OrientDeg        int
+	OrientConfidence float64
+	ScriptName       string
+	ScriptConfidence float64
+}
@@ -0,0 +1,19 @@
+package gosseract
+
+// OutputFormat specifies a renderer to attach to a ProcessPages run.
+// Multiple formats can be combined in a single call, and Tesseract will
+// render every one of them from the same recognition pass.
+type OutputFormat int
+
+const (
+	// OutputText renders plain text, one file per page concatenated together.
+	OutputText OutputFormat = 1 << iota
+	// OutputHOCR renders hOCR (HTML-based OCR markup).
+	OutputHOCR
+	// OutputPDF renders a searchable PDF with an invisible text layer.
+	OutputPDF
+	// OutputALTO renders ALTO XML.
+	OutputALTO
+	// OutputTSV renders tab-separated layout/confidence data.
+	OutputTSV
+)
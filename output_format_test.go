@@ -0,0 +1,25 @@
+package gosseract
+
+import "testing"
+
+func TestOutputFormatBitmask(t *testing.T) {
+	formats := []OutputFormat{OutputText, OutputHOCR, OutputPDF, OutputALTO, OutputTSV}
+	for i, a := range formats {
+		for j, b := range formats {
+			if i == j {
+				continue
+			}
+			if a&b != 0 {
+				t.Fatalf("%v and %v overlap, expected distinct bits", a, b)
+			}
+		}
+	}
+
+	combined := OutputPDF | OutputTSV
+	if combined&OutputPDF == 0 || combined&OutputTSV == 0 {
+		t.Fatalf("combined mask %v should contain both OutputPDF and OutputTSV", combined)
+	}
+	if combined&OutputHOCR != 0 {
+		t.Fatalf("combined mask %v should not contain OutputHOCR", combined)
+	}
+}
@@ -0,0 +1,18 @@
+package gosseract
+
+// PageIteratorLevel represents the granularity at which a ResultIterator
+// walks the page, mirroring tesseract::PageIteratorLevel.
+type PageIteratorLevel int
+
+const (
+	// PageIteratorLevelBlock iterates over blocks of text.
+	PageIteratorLevelBlock PageIteratorLevel = iota
+	// PageIteratorLevelPara iterates over paragraphs.
+	PageIteratorLevelPara
+	// PageIteratorLevelTextline iterates over lines of text.
+	PageIteratorLevelTextline
+	// PageIteratorLevelWord iterates over words.
+	PageIteratorLevelWord
+	// PageIteratorLevelSymbol iterates over individual symbols/characters.
+	PageIteratorLevelSymbol
+)
@@ -0,0 +1,18 @@
+package gosseract
+
+import "testing"
+
+func TestPageIteratorLevelOrdering(t *testing.T) {
+	levels := []PageIteratorLevel{
+		PageIteratorLevelBlock,
+		PageIteratorLevelPara,
+		PageIteratorLevelTextline,
+		PageIteratorLevelWord,
+		PageIteratorLevelSymbol,
+	}
+	for i, level := range levels {
+		if int(level) != i {
+			t.Fatalf("expected %v to have value %d, got %d", level, i, int(level))
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package gosseract
+
+// #include <stdlib.h>
+// #include "tessbridge.h"
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// PreprocessOptions selects which Leptonica-based preprocessing steps to run
+// over the client's image before recognition. Steps are applied in the fixed
+// order grayscale, background removal, otsu threshold, deskew, and scale,
+// regardless of struct field order. Background removal runs before
+// thresholding because pixBackgroundNormSimple needs an 8bpp/color pix, which
+// Otsu thresholding would have already collapsed to 1bpp.
+type PreprocessOptions struct {
+	Deskew        bool
+	Grayscale     bool
+	OtsuThreshold bool
+	// ScaleTo is the target DPI; 0 disables scaling. If the source pix has no
+	// embedded resolution (e.g. most PNGs, or any image loaded via
+	// SetImageFromRawData), a source DPI of 96 is assumed.
+	ScaleTo          int
+	RemoveBackground bool
+}
+
+// SetPreprocessing configures the Leptonica pipeline run over the pix image
+// inside `prepare`, for both SetImage and SetImageFromBytes. Preprocessing
+// only runs once per loaded image even across repeated Text() calls; call
+// SetImage/SetImageFromBytes again to load a fresh image and re-trigger it.
+func (client *Client) SetPreprocessing(options PreprocessOptions) *Client {
+	client.preprocessOptions = &options
+	client.preprocessed = false
+	return client
+}
+
+// SavePreprocessedImage writes the client's current pix image to path, which
+// is useful for inspecting the effect of SetPreprocessing. The image must
+// have been loaded (and, if configured, preprocessed) via a prior prepare()
+// call, e.g. by calling Text() first.
+func (client *Client) SavePreprocessedImage(path string) error {
+	if client.pixImage == nil {
+		return fmt.Errorf("no image loaded yet, call Text() or HOCRText() first")
+	}
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	if res := C.SavePixImage(client.pixImage, cpath); res != 0 {
+		return fmt.Errorf("failed to save preprocessed image to %q", path)
+	}
+	return nil
+}
+
+func (client *Client) preprocess() {
+	if client.preprocessOptions == nil || client.preprocessed || client.pixImage == nil {
+		return
+	}
+	opts := C.PreprocessOptions{
+		deskew:           C.bool(client.preprocessOptions.Deskew),
+		grayscale:        C.bool(client.preprocessOptions.Grayscale),
+		otsuThreshold:    C.bool(client.preprocessOptions.OtsuThreshold),
+		scaleToDPI:       C.int(client.preprocessOptions.ScaleTo),
+		removeBackground: C.bool(client.preprocessOptions.RemoveBackground),
+	}
+	client.pixImage = C.PreprocessPixImage(client.pixImage, opts)
+	client.preprocessed = true
+}
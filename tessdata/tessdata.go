@@ -0,0 +1,68 @@
+// Package tessdata lets a gosseract user compile traineddata files into their
+// binary via go:embed and have the client extract and use them transparently,
+// instead of depending on a tessdata directory being present on the host.
+//
+// Typical usage:
+//
+//	//go:embed tessdata/*.traineddata
+//	var embedded embed.FS
+//
+//	func init() {
+//		tessdata.Embed(embedded, "tessdata")
+//	}
+//
+//	client := gosseract.NewClient().UseEmbeddedTessdata()
+package tessdata
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string][]byte{}
+)
+
+// Register makes data available under name (e.g. "eng.traineddata") to any
+// client that calls Client.UseEmbeddedTessdata.
+func Register(name string, data []byte) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = data
+}
+
+// Embed walks dir within fsys and registers every "*.traineddata" file it
+// finds under its base name, so a single //go:embed directive is enough to
+// make a whole set of languages available.
+func Embed(fsys embed.FS, dir string) error {
+	return fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".traineddata") {
+			return nil
+		}
+		data, err := fsys.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		Register(path.Base(p), data)
+		return nil
+	})
+}
+
+// All returns a copy of every traineddata file registered so far, keyed by
+// file name.
+func All() map[string][]byte {
+	mu.RLock()
+	defer mu.RUnlock()
+	out := make(map[string][]byte, len(registry))
+	for name, data := range registry {
+		out[name] = data
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package tessdata
+
+import (
+	"embed"
+	"testing"
+)
+
+//go:embed testdata/*.traineddata
+var embedded embed.FS
+
+func TestRegisterAndAll(t *testing.T) {
+	Register("eng.traineddata", []byte("eng-data"))
+	Register("fra.traineddata", []byte("fra-data"))
+
+	all := All()
+	if string(all["eng.traineddata"]) != "eng-data" {
+		t.Fatalf("expected eng.traineddata to be registered, got %q", all["eng.traineddata"])
+	}
+	if string(all["fra.traineddata"]) != "fra-data" {
+		t.Fatalf("expected fra.traineddata to be registered, got %q", all["fra.traineddata"])
+	}
+
+	all["eng.traineddata"] = []byte("mutated")
+	if string(All()["eng.traineddata"]) != "eng-data" {
+		t.Fatalf("All() should return a copy, mutation leaked into the registry")
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	if err := Embed(embedded, "testdata"); err != nil {
+		t.Fatalf("Embed returned an error: %v", err)
+	}
+
+	all := All()
+	if _, ok := all["osd.traineddata"]; !ok {
+		t.Fatalf("expected osd.traineddata to be registered after Embed, got %v", all)
+	}
+}